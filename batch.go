@@ -0,0 +1,131 @@
+package nann
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// maxFanOut returns the largest fanOut across m's layers, used to size
+// scratch buffers that can hold any of m's intermediate activations.
+func (m Model) maxFanOut() int {
+	var mx int
+	for _, l := range m.Layers {
+		if _, fanOut := l.Shape(); fanOut > mx {
+			mx = fanOut
+		}
+	}
+	return mx
+}
+
+// newScratch allocates the pair of ping-pong buffers forwardScratch reuses
+// across calls to avoid allocating on every Forward.
+func (m Model) newScratch() [2][]float32 {
+	n := m.maxFanOut()
+	return [2][]float32{make([]float32, n), make([]float32, n)}
+}
+
+// forwardScratch is the shared implementation behind Forward, ForwardBatch,
+// and Predictor.Predict: it writes each layer's intermediate activations
+// into the given pair of preallocated scratch buffers, ping-ponging between
+// them without ever aliasing in or out, then copies the final activation
+// into out (growing it if necessary).
+func (m Model) forwardScratch(in, out []float32, scratch [2][]float32) []float32 {
+	cur := in
+	for i, l := range m.Layers {
+		_, fanOut := l.Shape()
+		next := scratch[i%2][:fanOut]
+		clear(next)
+		next = l.Forward(cur, next)
+		b, a := m.Biases[i], m.ActivFn[i]
+		for j, x := range next {
+			next[j] = a.Apply(x + b)
+		}
+		cur = next
+	}
+	if n := len(cur); n < len(out) {
+		out = out[:n]
+	} else if n > len(out) {
+		out = slices.Grow(out, n-len(out))[:n]
+	}
+	copy(out, cur)
+	return out
+}
+
+// ForwardBatch runs Forward over each sample in inputs, writing the result
+// for inputs[i] into outputs[i], sharding the batch across a
+// GOMAXPROCS-sized worker pool. Each worker allocates its own pair of
+// scratch buffers, sized to the largest fanOut across m's layers, once per
+// ForwardBatch call and reuses them for every sample in its shard, so for a
+// model built entirely from allocation-free layers (e.g. plain DenseLayer),
+// the allocations per call are the pool's goroutines, their closures and
+// their scratch buffers, independent of the number of samples; buffers are
+// not kept between ForwardBatch calls. Layers that allocate scratch space
+// inside their own Forward (e.g. Conv2DLayer, QuantDenseLayer) still allocate on every call;
+// ForwardBatch does nothing to avoid that.
+func (m Model) ForwardBatch(inputs, outputs [][]float32) {
+	if len(inputs) != len(outputs) {
+		panic("nann: inputs and outputs must have equal length")
+	}
+	if len(inputs) == 0 {
+		return
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+	chunk := (len(inputs) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(inputs); start += chunk {
+		end := start + chunk
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			scratch := m.newScratch()
+			for i := start; i < end; i++ {
+				outputs[i] = m.forwardScratch(inputs[i], outputs[i], scratch)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// Predictor owns a Model's scratch buffers and output slices across calls,
+// targeted at server workloads where p99 latency matters and GC pressure
+// from per-call allocation is a real problem.
+type Predictor struct {
+	model   *Model
+	scratch sync.Pool
+	out     sync.Pool
+}
+
+// NewPredictor returns a Predictor serving m.
+func NewPredictor(m *Model) *Predictor {
+	p := &Predictor{model: m}
+	p.scratch.New = func() any { return m.newScratch() }
+	_, lastOut := m.Shape()
+	p.out.New = func() any { return make([]float32, lastOut) }
+	return p
+}
+
+// Predict runs p's Model on in and returns the result, drawing its scratch
+// buffers and output slice from pools instead of allocating (beyond the
+// sync.Pool bookkeeping itself, and whatever the model's own layers
+// allocate inside Forward, e.g. Conv2DLayer or QuantDenseLayer). The
+// returned slice should be returned to the pool via Release once the
+// caller is done with it.
+func (p *Predictor) Predict(in []float32) []float32 {
+	scratch := p.scratch.Get().([2][]float32)
+	out := p.out.Get().([]float32)
+	out = p.model.forwardScratch(in, out, scratch)
+	p.scratch.Put(scratch)
+	return out
+}
+
+// Release returns an output slice obtained from Predict back to the pool.
+func (p *Predictor) Release(out []float32) { p.out.Put(out) }
@@ -0,0 +1,60 @@
+package nann
+
+import "testing"
+
+// newAgreementModel is the 2-layer model from the Forward/ForwardBatch
+// disagreement report: Dense(3,4)+LRelu feeding Dense(4,2)+Ident.
+func newAgreementModel() *Model {
+	m := &Model{}
+	d0 := DenseLayer{
+		{0.1, -0.2, 0.3, 0.4},
+		{-0.5, 0.6, -0.7, 0.8},
+		{0.9, -1.0, 1.1, -1.2},
+	}
+	d1 := DenseLayer{
+		{0.2, -0.1},
+		{-0.3, 0.4},
+		{0.5, -0.6},
+		{-0.7, 0.8},
+	}
+	m.AddLayer(d0, LRelu())
+	m.AddLayer(d1, Ident())
+	return m
+}
+
+// TestForwardAgreesWithForwardBatch guards against Forward and
+// ForwardBatch/Predictor silently disagreeing on the same model and input
+// (they used to: Forward's in/out ping-pong could alias the caller's input
+// slice and accumulate onto stale data).
+func TestForwardAgreesWithForwardBatch(t *testing.T) {
+	m := newAgreementModel()
+	in := []float32{0.5, -0.3, 0.8}
+
+	want := m.Forward(in, make([]float32, 2))
+
+	gotBatch := [][]float32{make([]float32, 2)}
+	m.ForwardBatch([][]float32{in}, gotBatch)
+	if !floatsEqual(gotBatch[0], want) {
+		t.Errorf("ForwardBatch() = %v, Forward() = %v", gotBatch[0], want)
+	}
+
+	p := NewPredictor(m)
+	gotPredict := p.Predict(in)
+	if !floatsEqual(gotPredict, want) {
+		t.Errorf("Predictor.Predict() = %v, Forward() = %v", gotPredict, want)
+	}
+}
+
+// TestForwardDoesNotMutateInput guards against Forward writing into the
+// caller's input slice when its ping-pong buffers used to alias it.
+func TestForwardDoesNotMutateInput(t *testing.T) {
+	m := newAgreementModel()
+	in := []float32{0.5, -0.3, 0.8}
+	want := []float32{0.5, -0.3, 0.8}
+
+	m.Forward(in, make([]float32, 2))
+
+	if !floatsEqual(in, want) {
+		t.Errorf("Forward mutated its input slice: in = %v, want %v", in, want)
+	}
+}
@@ -0,0 +1,215 @@
+package nann
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// LayerND is implemented by layers whose connectivity isn't purely 1D
+// dense, exposing the layer's true multi-dimensional input/output shape in
+// addition to the flat element counts reported by Shape.
+type LayerND interface {
+	Layer
+	// ShapeND returns the layer's input shape dimensions followed by its
+	// output shape dimensions, e.g. for Conv2DLayer: [cIn, hIn, wIn, cOut,
+	// hOut, wOut].
+	ShapeND() []int
+}
+
+// Conv2DLayer is a 2D convolution over a cIn-channel, hIn x wIn input,
+// implemented as im2col followed by the existing DenseLayer matmul.
+type Conv2DLayer struct {
+	cIn, hIn, wIn   int
+	cOut, kh, kw    int
+	stride, padding int
+	hOut, wOut      int
+	weights         DenseLayer // rows = cIn*kh*kw (im2col patch size), cols = cOut.
+}
+
+// NewConv2DLayer returns a Conv2DLayer over a cIn-channel, hIn x wIn input,
+// producing cOut output channels with a kh x kw kernel, the given stride,
+// and the given zero-padding on each side.
+func NewConv2DLayer(cIn, hIn, wIn, cOut, kh, kw, stride, padding int) *Conv2DLayer {
+	return &Conv2DLayer{
+		cIn: cIn, hIn: hIn, wIn: wIn,
+		cOut: cOut, kh: kh, kw: kw,
+		stride: stride, padding: padding,
+		hOut:    convOutDim(hIn, kh, stride, padding),
+		wOut:    convOutDim(wIn, kw, stride, padding),
+		weights: NewDenseLayer(cIn*kh*kw, cOut),
+	}
+}
+
+func convOutDim(in, k, stride, padding int) int { return (in+2*padding-k)/stride + 1 }
+
+func (l *Conv2DLayer) Shape() (int, int) { return l.cIn * l.hIn * l.wIn, l.cOut * l.hOut * l.wOut }
+
+func (l *Conv2DLayer) ShapeND() []int {
+	return []int{l.cIn, l.hIn, l.wIn, l.cOut, l.hOut, l.wOut}
+}
+
+// InitWeights initializes the layer with Xavier init specialized on the
+// im2col patch size, fanIn = kh*kw*cIn.
+func (l *Conv2DLayer) InitWeights(r *rand.Rand) { l.weights.InitWeights(r) }
+
+// im2col gathers the receptive field for output position (oh, ow) from in
+// into patch, in cIn-major, then kh, then kw order, zero-padding reads that
+// fall outside the input.
+func (l *Conv2DLayer) im2col(in []float32, oh, ow int, patch []float32) {
+	i := 0
+	for c := 0; c < l.cIn; c++ {
+		for kh := 0; kh < l.kh; kh++ {
+			ih := oh*l.stride + kh - l.padding
+			for kw := 0; kw < l.kw; kw++ {
+				iw := ow*l.stride + kw - l.padding
+				if ih < 0 || ih >= l.hIn || iw < 0 || iw >= l.wIn {
+					patch[i] = 0
+				} else {
+					patch[i] = in[(c*l.hIn+ih)*l.wIn+iw]
+				}
+				i++
+			}
+		}
+	}
+}
+
+func (l *Conv2DLayer) Forward(in, out []float32) []float32 {
+	if n := l.cIn * l.hIn * l.wIn; len(in) != n {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if n := l.cOut * l.hOut * l.wOut; len(out) != n {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	patch := make([]float32, l.cIn*l.kh*l.kw)
+	patchOut := make([]float32, l.cOut)
+	for oh := 0; oh < l.hOut; oh++ {
+		for ow := 0; ow < l.wOut; ow++ {
+			l.im2col(in, oh, ow, patch)
+			clear(patchOut)
+			l.weights.Forward(patch, patchOut)
+			for oc, x := range patchOut {
+				out[(oc*l.hOut+oh)*l.wOut+ow] += x
+			}
+		}
+	}
+	return out
+}
+
+// MaxPool2DLayer is a 2D max-pooling layer over a cIn-channel, hIn x wIn
+// input; it has no trainable parameters.
+type MaxPool2DLayer struct {
+	c, hIn, wIn    int
+	kh, kw, stride int
+	hOut, wOut     int
+}
+
+// NewMaxPool2DLayer returns a MaxPool2DLayer pooling a c-channel, hIn x wIn
+// input with a kh x kw window and the given stride.
+func NewMaxPool2DLayer(c, hIn, wIn, kh, kw, stride int) MaxPool2DLayer {
+	return MaxPool2DLayer{c, hIn, wIn, kh, kw, stride, convOutDim(hIn, kh, stride, 0), convOutDim(wIn, kw, stride, 0)}
+}
+
+func (l MaxPool2DLayer) Shape() (int, int) { return l.c * l.hIn * l.wIn, l.c * l.hOut * l.wOut }
+
+func (l MaxPool2DLayer) ShapeND() []int { return []int{l.c, l.hIn, l.wIn, l.c, l.hOut, l.wOut} }
+
+func (MaxPool2DLayer) InitWeights(*rand.Rand) {}
+
+func (l MaxPool2DLayer) Forward(in, out []float32) []float32 {
+	if n := l.c * l.hIn * l.wIn; len(in) != n {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if n := l.c * l.hOut * l.wOut; len(out) != n {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	for c := 0; c < l.c; c++ {
+		for oh := 0; oh < l.hOut; oh++ {
+			for ow := 0; ow < l.wOut; ow++ {
+				mx := float32(math.Inf(-1))
+				for kh := 0; kh < l.kh; kh++ {
+					ih := oh*l.stride + kh
+					for kw := 0; kw < l.kw; kw++ {
+						iw := ow*l.stride + kw
+						if x := in[(c*l.hIn+ih)*l.wIn+iw]; x > mx {
+							mx = x
+						}
+					}
+				}
+				out[(c*l.hOut+oh)*l.wOut+ow] += mx
+			}
+		}
+	}
+	return out
+}
+
+// AvgPool2DLayer is a 2D average-pooling layer over a cIn-channel, hIn x wIn
+// input; it has no trainable parameters.
+type AvgPool2DLayer struct {
+	c, hIn, wIn    int
+	kh, kw, stride int
+	hOut, wOut     int
+}
+
+// NewAvgPool2DLayer returns an AvgPool2DLayer pooling a c-channel, hIn x wIn
+// input with a kh x kw window and the given stride.
+func NewAvgPool2DLayer(c, hIn, wIn, kh, kw, stride int) AvgPool2DLayer {
+	return AvgPool2DLayer{c, hIn, wIn, kh, kw, stride, convOutDim(hIn, kh, stride, 0), convOutDim(wIn, kw, stride, 0)}
+}
+
+func (l AvgPool2DLayer) Shape() (int, int) { return l.c * l.hIn * l.wIn, l.c * l.hOut * l.wOut }
+
+func (l AvgPool2DLayer) ShapeND() []int { return []int{l.c, l.hIn, l.wIn, l.c, l.hOut, l.wOut} }
+
+func (AvgPool2DLayer) InitWeights(*rand.Rand) {}
+
+func (l AvgPool2DLayer) Forward(in, out []float32) []float32 {
+	if n := l.c * l.hIn * l.wIn; len(in) != n {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if n := l.c * l.hOut * l.wOut; len(out) != n {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	n := float32(l.kh * l.kw)
+	for c := 0; c < l.c; c++ {
+		for oh := 0; oh < l.hOut; oh++ {
+			for ow := 0; ow < l.wOut; ow++ {
+				var sum float32
+				for kh := 0; kh < l.kh; kh++ {
+					ih := oh*l.stride + kh
+					for kw := 0; kw < l.kw; kw++ {
+						iw := ow*l.stride + kw
+						sum += in[(c*l.hIn+ih)*l.wIn+iw]
+					}
+				}
+				out[(c*l.hOut+oh)*l.wOut+ow] += sum / n
+			}
+		}
+	}
+	return out
+}
+
+// FlattenLayer reshapes a c x h x w input into a flat vector for
+// consumption by a DenseLayer; it has no trainable parameters and its
+// Forward is a copy.
+type FlattenLayer struct{ c, h, w int }
+
+// NewFlattenLayer returns a FlattenLayer for a c x h x w input.
+func NewFlattenLayer(c, h, w int) FlattenLayer { return FlattenLayer{c, h, w} }
+
+func (l FlattenLayer) Shape() (int, int) { n := l.c * l.h * l.w; return n, n }
+
+func (l FlattenLayer) ShapeND() []int { return []int{l.c, l.h, l.w, l.c * l.h * l.w} }
+
+func (FlattenLayer) InitWeights(*rand.Rand) {}
+
+func (l FlattenLayer) Forward(in, out []float32) []float32 {
+	if n := l.c * l.h * l.w; len(in) != n {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if n := l.c * l.h * l.w; len(out) != n {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	copy(out, in)
+	return out
+}
@@ -0,0 +1,123 @@
+package nann
+
+import "testing"
+
+func TestConv2DLayerForward(t *testing.T) {
+	l := NewConv2DLayer(1, 3, 3, 1, 2, 2, 1, 0)
+	for _, row := range l.weights {
+		for j := range row {
+			row[j] = 1
+		}
+	}
+	in := []float32{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	}
+	out := make([]float32, 4)
+	got := l.Forward(in, out)
+	want := []float32{12, 16, 24, 28} // sum of each 2x2 window.
+	if !floatsEqual(got, want) {
+		t.Errorf("Conv2DLayer.Forward() = %v, want %v", got, want)
+	}
+}
+
+func TestConv2DLayerForwardPadding(t *testing.T) {
+	l := NewConv2DLayer(1, 2, 2, 1, 2, 2, 1, 1)
+	for _, row := range l.weights {
+		for j := range row {
+			row[j] = 1
+		}
+	}
+	in := []float32{
+		1, 2,
+		3, 4,
+	}
+	_, fanOut := l.Shape()
+	out := make([]float32, fanOut)
+	got := l.Forward(in, out)
+	// hOut = wOut = 3; each window sums whatever of the input falls inside
+	// it, treating out-of-bounds reads as zero.
+	want := []float32{1, 3, 2, 4, 10, 6, 3, 7, 4}
+	if !floatsEqual(got, want) {
+		t.Errorf("Conv2DLayer.Forward() with padding = %v, want %v", got, want)
+	}
+}
+
+func TestMaxPool2DLayerForward(t *testing.T) {
+	l := NewMaxPool2DLayer(1, 4, 4, 2, 2, 2)
+	in := []float32{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	out := make([]float32, 4)
+	got := l.Forward(in, out)
+	want := []float32{6, 8, 14, 16}
+	if !floatsEqual(got, want) {
+		t.Errorf("MaxPool2DLayer.Forward() = %v, want %v", got, want)
+	}
+}
+
+func TestAvgPool2DLayerForward(t *testing.T) {
+	l := NewAvgPool2DLayer(1, 4, 4, 2, 2, 2)
+	in := []float32{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	out := make([]float32, 4)
+	got := l.Forward(in, out)
+	want := []float32{3.5, 5.5, 11.5, 13.5}
+	if !floatsEqual(got, want) {
+		t.Errorf("AvgPool2DLayer.Forward() = %v, want %v", got, want)
+	}
+}
+
+func TestFlattenLayerForward(t *testing.T) {
+	l := NewFlattenLayer(2, 2, 2)
+	in := []float32{1, 2, 3, 4, 5, 6, 7, 8}
+	out := make([]float32, 8)
+	got := l.Forward(in, out)
+	if !floatsEqual(got, in) {
+		t.Errorf("FlattenLayer.Forward() = %v, want %v", got, in)
+	}
+}
+
+func TestPoolAndFlattenForwardShapeMismatch(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func()
+	}{
+		{"MaxPool2D/in", func() { NewMaxPool2DLayer(1, 4, 4, 2, 2, 2).Forward(make([]float32, 15), make([]float32, 4)) }},
+		{"MaxPool2D/out", func() { NewMaxPool2DLayer(1, 4, 4, 2, 2, 2).Forward(make([]float32, 16), make([]float32, 1)) }},
+		{"AvgPool2D/in", func() { NewAvgPool2DLayer(1, 4, 4, 2, 2, 2).Forward(make([]float32, 15), make([]float32, 4)) }},
+		{"AvgPool2D/out", func() { NewAvgPool2DLayer(1, 4, 4, 2, 2, 2).Forward(make([]float32, 16), make([]float32, 1)) }},
+		{"Flatten/in", func() { NewFlattenLayer(2, 2, 2).Forward(make([]float32, 7), make([]float32, 8)) }},
+		{"Flatten/out", func() { NewFlattenLayer(2, 2, 2).Forward(make([]float32, 8), make([]float32, 7)) }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Forward did not panic on shape mismatch", c.name)
+				}
+			}()
+			c.run()
+		})
+	}
+}
+
+func floatsEqual(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, x := range a {
+		if d := x - b[i]; d > 1e-4 || d < -1e-4 {
+			return false
+		}
+	}
+	return true
+}
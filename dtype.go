@@ -0,0 +1,38 @@
+package nann
+
+// DType identifies the numeric representation a Layer stores its weights
+// in, for introspection (e.g. logging or summarizing a Model). It is purely
+// descriptive: Model.Forward dispatches through the Layer interface alone
+// and never consults DType.
+type DType uint8
+
+const (
+	DTypeFloat32 DType = iota
+	DTypeFloat16
+	DTypeBFloat16
+	DTypeFixed8
+)
+
+func (d DType) String() string {
+	switch d {
+	case DTypeFloat32:
+		return "float32"
+	case DTypeFloat16:
+		return "float16"
+	case DTypeBFloat16:
+		return "bfloat16"
+	case DTypeFixed8:
+		return "fixed8"
+	default:
+		return "unknown"
+	}
+}
+
+// Typed is implemented by layers that can report the numeric precision of
+// their stored weights, so callers can introspect a Model (e.g. to print a
+// summary or estimate memory use) without type-asserting every layer. Typed
+// is not consulted by Forward; every Layer is invoked identically regardless
+// of DType.
+type Typed interface {
+	DType() DType
+}
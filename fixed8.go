@@ -1,5 +1,7 @@
 package nann
 
+import "math"
+
 // Fixed8 represents an extremely low precision floating point number used in the nann architecture.
 //
 // The Fixed8 is represented as follows:
@@ -12,5 +14,22 @@ package nann
 // The smallest infinitesimal values are -0.125 and +0.125.
 type Fixed8 int8
 
+// fixed8Scale is the value of the least significant bit of the decimal part.
+const fixed8Scale = 1.0 / 8
+
+// fixed8Max is the largest value representable by a Fixed8.
+const fixed8Max = 15.875
+
 // Float32 returns a float32 representation of this fixed value.
-func (x Fixed8) Float32() float32 { return float32(x) / 8 }
+func (x Fixed8) Float32() float32 { return float32(x) * fixed8Scale }
+
+// NewFixed8 converts x to the nearest representable Fixed8, rounding to
+// nearest and saturating to [-16, 15.875].
+func NewFixed8(x float32) Fixed8 {
+	if x > fixed8Max {
+		x = fixed8Max
+	} else if x < -16 {
+		x = -16
+	}
+	return Fixed8(math.Round(float64(x / fixed8Scale)))
+}
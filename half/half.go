@@ -0,0 +1,102 @@
+// Package half implements compact half-precision floating point types:
+// Float16 (IEEE 754 binary16) and BFloat16 (the top 16 bits of a float32,
+// as used by Google Brain / TensorFlow).
+package half
+
+import "math"
+
+// Float16 is an IEEE 754 binary16 value: 1 sign bit, 5 exponent bits, 10
+// mantissa bits.
+type Float16 uint16
+
+// NewFloat16 converts f to the nearest Float16, rounding to nearest even.
+// Values that overflow the exponent range saturate to ±Inf; NaNs are
+// preserved as NaNs.
+func NewFloat16(f float32) Float16 {
+	bits := math.Float32bits(f)
+	sign := uint16((bits >> 16) & 0x8000)
+	exp := int32((bits>>23)&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case (bits>>23)&0xff == 0xff:
+		if mant != 0 {
+			return Float16(sign | 0x7e00 | uint16(mant>>13)) // NaN
+		}
+		return Float16(sign | 0x7c00) // ±Inf
+	case exp <= 0:
+		if exp < -10 {
+			return Float16(sign) // underflows to ±0
+		}
+		mant |= 0x800000 // restore the implicit leading 1
+		shift := uint32(14 - exp)
+		m := mant >> shift
+		rem, halfway := mant&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > halfway || (rem == halfway && m&1 == 1) {
+			m++
+		}
+		return Float16(sign | uint16(m))
+	case exp >= 0x1f:
+		return Float16(sign | 0x7c00) // overflows to ±Inf
+	default:
+		m := mant >> 13
+		rem := mant & 0x1fff
+		if rem > 0x1000 || (rem == 0x1000 && m&1 == 1) {
+			m++
+			if m == 0x400 {
+				m = 0
+				exp++
+				if exp >= 0x1f {
+					return Float16(sign | 0x7c00)
+				}
+			}
+		}
+		return Float16(sign | uint16(exp)<<10 | uint16(m))
+	}
+}
+
+// Float32 returns the float32 value of h.
+func (h Float16) Float32() float32 {
+	bits := uint32(h)
+	sign := (bits & 0x8000) << 16
+	exp := (bits >> 10) & 0x1f
+	mant := bits & 0x3ff
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		e := int32(-14)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+		return math.Float32frombits(sign | uint32(e+127)<<23 | mant<<13)
+	case 0x1f:
+		if mant == 0 {
+			return math.Float32frombits(sign | 0x7f800000)
+		}
+		return math.Float32frombits(sign | 0x7fc00000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp-15+127)<<23 | mant<<13)
+	}
+}
+
+// BFloat16 is Google Brain's bfloat16: the top 16 bits of a float32 (1 sign
+// bit, 8 exponent bits, 7 mantissa bits).
+type BFloat16 uint16
+
+// NewBFloat16 converts f to the nearest BFloat16, rounding to nearest even.
+func NewBFloat16(f float32) BFloat16 {
+	bits := math.Float32bits(f)
+	if bits&0x7fffffff > 0x7f800000 {
+		return BFloat16((bits >> 16) | 0x0040) // NaN: force the mantissa's top bit so it stays quiet.
+	}
+	bits += 0x7fff + (bits >> 16 & 1) // round to nearest even.
+	return BFloat16(bits >> 16)
+}
+
+// Float32 returns the float32 value of b.
+func (b BFloat16) Float32() float32 { return math.Float32frombits(uint32(b) << 16) }
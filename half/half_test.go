@@ -0,0 +1,99 @@
+package half
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for _, f := range []float32{
+		0, 1, -1, 0.5, -0.5, 3.14159, 65504, -65504, 1e-5, 6.1e-5,
+	} {
+		got := NewFloat16(f).Float32()
+		if want := f; math.Abs(float64(got-want)) > 0.01*math.Abs(float64(want))+1e-7 {
+			t.Errorf("NewFloat16(%v).Float32() = %v, want ~%v", f, got, want)
+		}
+	}
+}
+
+func TestFloat16Zero(t *testing.T) {
+	if got := NewFloat16(0).Float32(); got != 0 {
+		t.Errorf("NewFloat16(0).Float32() = %v, want 0", got)
+	}
+	if got := NewFloat16(float32(math.Copysign(0, -1))).Float32(); math.Signbit(float64(got)) != true {
+		t.Errorf("NewFloat16(-0).Float32() = %v, want -0", got)
+	}
+}
+
+func TestFloat16Inf(t *testing.T) {
+	if got := NewFloat16(float32(math.Inf(1))).Float32(); !math.IsInf(float64(got), 1) {
+		t.Errorf("NewFloat16(+Inf).Float32() = %v, want +Inf", got)
+	}
+	if got := NewFloat16(float32(math.Inf(-1))).Float32(); !math.IsInf(float64(got), -1) {
+		t.Errorf("NewFloat16(-Inf).Float32() = %v, want -Inf", got)
+	}
+	// Overflow also saturates to Inf.
+	if got := NewFloat16(1e9).Float32(); !math.IsInf(float64(got), 1) {
+		t.Errorf("NewFloat16(1e9).Float32() = %v, want +Inf", got)
+	}
+}
+
+func TestFloat16NaN(t *testing.T) {
+	if got := NewFloat16(float32(math.NaN())).Float32(); !math.IsNaN(float64(got)) {
+		t.Errorf("NewFloat16(NaN).Float32() = %v, want NaN", got)
+	}
+}
+
+func TestFloat16Subnormal(t *testing.T) {
+	// 2^-24 is the smallest positive subnormal binary16 value; 2^-25 rounds
+	// to zero (round to nearest even, tie rounds down to an even mantissa).
+	const smallest = 1.0 / (1 << 24)
+	if got := NewFloat16(smallest).Float32(); got != smallest {
+		t.Errorf("NewFloat16(2^-24).Float32() = %v, want %v", got, smallest)
+	}
+	if got := NewFloat16(smallest / 2).Float32(); got != 0 {
+		t.Errorf("NewFloat16(2^-25).Float32() = %v, want 0", got)
+	}
+}
+
+func TestBFloat16RoundTrip(t *testing.T) {
+	for _, f := range []float32{
+		0, 1, -1, 0.5, -0.5, 3.14159, 1e30, -1e30, 1e-30,
+	} {
+		got := NewBFloat16(f).Float32()
+		if want := f; math.Abs(float64(got-want)) > 0.02*math.Abs(float64(want))+1e-30 {
+			t.Errorf("NewBFloat16(%v).Float32() = %v, want ~%v", f, got, want)
+		}
+	}
+}
+
+func TestBFloat16Inf(t *testing.T) {
+	if got := NewBFloat16(float32(math.Inf(1))).Float32(); !math.IsInf(float64(got), 1) {
+		t.Errorf("NewBFloat16(+Inf).Float32() = %v, want +Inf", got)
+	}
+	if got := NewBFloat16(float32(math.Inf(-1))).Float32(); !math.IsInf(float64(got), -1) {
+		t.Errorf("NewBFloat16(-Inf).Float32() = %v, want -Inf", got)
+	}
+}
+
+func TestBFloat16NaN(t *testing.T) {
+	if got := NewBFloat16(float32(math.NaN())).Float32(); !math.IsNaN(float64(got)) {
+		t.Errorf("NewBFloat16(NaN).Float32() = %v, want NaN", got)
+	}
+}
+
+func TestFloat16RoundToEven(t *testing.T) {
+	// 1 + 2^-11 is exactly halfway between two representable binary16
+	// values; round-to-nearest-even should round down (mantissa stays even).
+	const down = 1 + 1.0/(1<<11)
+	if got, want := NewFloat16(down).Float32(), float32(1); got != want {
+		t.Errorf("NewFloat16(1+2^-11).Float32() = %v, want %v (round to even)", got, want)
+	}
+	// 1 + 3*2^-11 is exactly halfway between 1+2^-10 and 1+2*2^-10; the
+	// even neighbor is 1+2*2^-10, so it should round up to it.
+	const up = 1 + 3.0/(1<<11)
+	const upWant = 1 + 2.0/(1<<10)
+	if got := NewFloat16(up).Float32(); got != upWant {
+		t.Errorf("NewFloat16(1+3*2^-11).Float32() = %v, want %v (round to even)", got, upWant)
+	}
+}
@@ -0,0 +1,102 @@
+package nann
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/wenooij/nann/half"
+)
+
+// HalfDenseLayer is a DenseLayer that stores its weights as half.Float16
+// values, halving memory use relative to DenseLayer, and dequantizing on
+// the fly during Forward.
+//
+// The fanIn is represented in the first dimension while the fanOut is the
+// second dimension, mirroring DenseLayer.
+type HalfDenseLayer [][]half.Float16
+
+// NewHalfDenseLayer allocates a zeroed HalfDenseLayer of the given shape.
+func NewHalfDenseLayer(fanIn, fanOut int) HalfDenseLayer {
+	d := make(HalfDenseLayer, fanIn)
+	for i := range d {
+		d[i] = make([]half.Float16, fanOut)
+	}
+	return d
+}
+
+func (l HalfDenseLayer) Shape() (int, int) { return len(l), len(l[0]) }
+
+func (l HalfDenseLayer) DType() DType { return DTypeFloat16 }
+
+func (l HalfDenseLayer) InitWeights(r *rand.Rand) {
+	fanIn := len(l)
+	for _, row := range l {
+		fanOut := len(row)
+		for i := range row {
+			row[i] = half.NewFloat16((2*r.Float32() - 1) * xavier(fanIn, fanOut))
+		}
+	}
+}
+
+func (l HalfDenseLayer) Forward(in, out []float32) []float32 {
+	if len(in) != len(l) {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if len(out) != len(l[0]) {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	for i, x := range in {
+		row := l[i]
+		for j := range out {
+			out[j] += row[j].Float32() * x
+		}
+	}
+	return out
+}
+
+// BFloat16DenseLayer is a DenseLayer that stores its weights as
+// half.BFloat16 values, dequantizing on the fly during Forward.
+//
+// The fanIn is represented in the first dimension while the fanOut is the
+// second dimension, mirroring DenseLayer.
+type BFloat16DenseLayer [][]half.BFloat16
+
+// NewBFloat16DenseLayer allocates a zeroed BFloat16DenseLayer of the given
+// shape.
+func NewBFloat16DenseLayer(fanIn, fanOut int) BFloat16DenseLayer {
+	d := make(BFloat16DenseLayer, fanIn)
+	for i := range d {
+		d[i] = make([]half.BFloat16, fanOut)
+	}
+	return d
+}
+
+func (l BFloat16DenseLayer) Shape() (int, int) { return len(l), len(l[0]) }
+
+func (l BFloat16DenseLayer) DType() DType { return DTypeBFloat16 }
+
+func (l BFloat16DenseLayer) InitWeights(r *rand.Rand) {
+	fanIn := len(l)
+	for _, row := range l {
+		fanOut := len(row)
+		for i := range row {
+			row[i] = half.NewBFloat16((2*r.Float32() - 1) * xavier(fanIn, fanOut))
+		}
+	}
+}
+
+func (l BFloat16DenseLayer) Forward(in, out []float32) []float32 {
+	if len(in) != len(l) {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if len(out) != len(l[0]) {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	for i, x := range in {
+		row := l[i]
+		for j := range out {
+			out[j] += row[j].Float32() * x
+		}
+	}
+	return out
+}
@@ -3,7 +3,6 @@ package nann
 import (
 	"math"
 	"math/rand"
-	"slices"
 	"strconv"
 )
 
@@ -28,6 +27,8 @@ func NewDenseLayer(fanIn, fanOut int) DenseLayer {
 
 func (l DenseLayer) Shape() (int, int) { return len(l), len(l[0]) }
 
+func (l DenseLayer) DType() DType { return DTypeFloat32 }
+
 func (l DenseLayer) InitWeights(r *rand.Rand) {
 	fanIn := len(l)
 	for _, row := range l {
@@ -80,27 +81,13 @@ func (m Model) InitWeights(r *rand.Rand) {
 	}
 }
 
+// Forward runs m's layers over in, writing the final layer's activations
+// into out (growing it if necessary) and returning it. Forward allocates a
+// fresh pair of ping-pong scratch buffers on every call; callers making
+// repeated Forward calls (e.g. over a batch) should use ForwardBatch or a
+// Predictor instead, which reuse scratch buffers across calls.
 func (m Model) Forward(in, out []float32) []float32 {
-	for i := 0; ; {
-		l := m.Layers[i]
-		_, fanOut := l.Shape()
-		if n := fanOut; n < len(out) {
-			out = out[:n]
-		} else if n > len(out) {
-			out = slices.Grow(out, n-len(out))[:n]
-		}
-		out = l.Forward(in, out)
-		b := m.Biases[i]
-		a := m.ActivFn[i]
-		for i, x := range out {
-			out[i] = a.Apply(x + b)
-		}
-		if i++; i < len(m.Layers) {
-			in, out = out, in
-		} else {
-			return out
-		}
-	}
+	return m.forwardScratch(in, out, m.newScratch())
 }
 
 type ActivFn interface {
@@ -0,0 +1,78 @@
+package npy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/wenooij/nann"
+)
+
+func weightName(i int) string { return fmt.Sprintf("layer%d.weight", i) }
+func biasName(i int) string   { return fmt.Sprintf("layer%d.bias", i) }
+
+// SaveModel writes m's weights and per-layer biases to w as an uncompressed
+// .npz archive, with entries named layerN.weight (shape fanIn x fanOut) and
+// layerN.bias (a length-1 array), one pair per layer. SaveModel only
+// supports models whose layers are all nann.DenseLayer.
+func SaveModel(w io.Writer, m *nann.Model) error {
+	arrays := make(map[string]Array, 2*len(m.Layers))
+	for i, l := range m.Layers {
+		d, ok := l.(nann.DenseLayer)
+		if !ok {
+			return fmt.Errorf("npy: layer %d: SaveModel only supports nann.DenseLayer, got %T", i, l)
+		}
+		fanIn, fanOut := d.Shape()
+		data := make([]float32, 0, fanIn*fanOut)
+		for _, row := range d {
+			data = append(data, row...)
+		}
+		arrays[weightName(i)] = Array{Shape: []int{fanIn, fanOut}, Data: data}
+		arrays[biasName(i)] = Array{Shape: []int{1}, Data: []float32{m.Biases[i]}}
+	}
+	return WriteZip(w, arrays)
+}
+
+// LoadModel reads weights and biases previously written by SaveModel (or an
+// equivalent numpy.savez export using the same layerN.weight/layerN.bias
+// naming) from r, overwriting each of m's DenseLayer weights and biases in
+// place. m's layers must already be shaped to match the checkpoint.
+func LoadModel(r io.Reader, m *nann.Model) error {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	arrays, err := ReadZip(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return err
+	}
+
+	for i, l := range m.Layers {
+		d, ok := l.(nann.DenseLayer)
+		if !ok {
+			return fmt.Errorf("npy: layer %d: LoadModel only supports nann.DenseLayer, got %T", i, l)
+		}
+		fanIn, fanOut := d.Shape()
+
+		w, ok := arrays[weightName(i)]
+		if !ok {
+			return fmt.Errorf("npy: missing entry %q", weightName(i))
+		}
+		if len(w.Shape) != 2 || w.Shape[0] != fanIn || w.Shape[1] != fanOut {
+			return fmt.Errorf("npy: %s has shape %v, want (%d, %d)", weightName(i), w.Shape, fanIn, fanOut)
+		}
+		for j, row := range d {
+			copy(row, w.Data[j*fanOut:(j+1)*fanOut])
+		}
+
+		b, ok := arrays[biasName(i)]
+		if !ok {
+			return fmt.Errorf("npy: missing entry %q", biasName(i))
+		}
+		if len(b.Data) != 1 {
+			return fmt.Errorf("npy: %s has %d elements, want 1", biasName(i), len(b.Data))
+		}
+		m.Biases[i] = b.Data[0]
+	}
+	return nil
+}
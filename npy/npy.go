@@ -0,0 +1,154 @@
+// Package npy reads and writes NumPy .npy arrays and .npz archives, and
+// maps nann.Model weights to and from them for interop with
+// PyTorch/JAX-trained checkpoints exported via numpy.save or numpy.savez.
+package npy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const magic = "\x93NUMPY"
+
+// Array is an in-memory NumPy ndarray of float32 elements in C
+// (row-major) order.
+type Array struct {
+	Shape []int
+	Data  []float32
+}
+
+// Write encodes a in .npy format (version 1.0) to w.
+func Write(w io.Writer, a Array) error {
+	var shape strings.Builder
+	shape.WriteByte('(')
+	for i, d := range a.Shape {
+		if i > 0 {
+			shape.WriteString(", ")
+		}
+		shape.WriteString(strconv.Itoa(d))
+	}
+	if len(a.Shape) == 1 {
+		shape.WriteByte(',')
+	}
+	shape.WriteByte(')')
+
+	header := fmt.Sprintf("{'descr': '<f4', 'fortran_order': False, 'shape': %s, }", shape.String())
+	// Pad with spaces so magic+version+headerLen+header+'\n' is a multiple
+	// of 64 bytes, matching numpy's own writer.
+	const preambleLen = len(magic) + 2 + 2
+	if pad := 64 - (preambleLen+len(header)+1)%64; pad != 64 {
+		header += strings.Repeat(" ", pad)
+	}
+	header += "\n"
+
+	if _, err := io.WriteString(w, magic); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{1, 0}); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(header))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, a.Data)
+}
+
+// Read decodes a float32 .npy array from r.
+func Read(r io.Reader) (Array, error) {
+	var magicBuf [len(magic)]byte
+	if _, err := io.ReadFull(r, magicBuf[:]); err != nil {
+		return Array{}, err
+	}
+	if string(magicBuf[:]) != magic {
+		return Array{}, fmt.Errorf("npy: bad magic %q", magicBuf)
+	}
+
+	var version [2]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return Array{}, err
+	}
+	var headerLen int
+	if version[0] == 1 {
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return Array{}, err
+		}
+		headerLen = int(n)
+	} else {
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return Array{}, err
+		}
+		headerLen = int(n)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Array{}, err
+	}
+	descr, fortranOrder, shape, err := parseHeader(string(header))
+	if err != nil {
+		return Array{}, err
+	}
+	if descr != "<f4" {
+		return Array{}, fmt.Errorf("npy: unsupported dtype %q, want <f4", descr)
+	}
+	if fortranOrder {
+		return Array{}, errors.New("npy: fortran_order arrays are not supported")
+	}
+
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+	data := make([]float32, n)
+	if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+		return Array{}, err
+	}
+	return Array{Shape: shape, Data: data}, nil
+}
+
+var (
+	descrRe   = regexp.MustCompile(`'descr':\s*'([^']+)'`)
+	fortranRe = regexp.MustCompile(`'fortran_order':\s*(True|False)`)
+	shapeRe   = regexp.MustCompile(`'shape':\s*\(([^)]*)\)`)
+)
+
+func parseHeader(h string) (descr string, fortranOrder bool, shape []int, err error) {
+	m := descrRe.FindStringSubmatch(h)
+	if m == nil {
+		return "", false, nil, errors.New("npy: header missing descr")
+	}
+	descr = m[1]
+
+	m = fortranRe.FindStringSubmatch(h)
+	if m == nil {
+		return "", false, nil, errors.New("npy: header missing fortran_order")
+	}
+	fortranOrder = m[1] == "True"
+
+	m = shapeRe.FindStringSubmatch(h)
+	if m == nil {
+		return "", false, nil, errors.New("npy: header missing shape")
+	}
+	for _, tok := range strings.Split(m[1], ",") {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		d, err := strconv.Atoi(tok)
+		if err != nil {
+			return "", false, nil, fmt.Errorf("npy: bad shape entry %q: %w", tok, err)
+		}
+		shape = append(shape, d)
+	}
+	return descr, fortranOrder, shape, nil
+}
@@ -0,0 +1,120 @@
+package npy
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/wenooij/nann"
+)
+
+func TestArrayRoundTrip(t *testing.T) {
+	a := Array{Shape: []int{2, 3}, Data: []float32{1, 2, 3, 4, 5, 6}}
+	var buf bytes.Buffer
+	if err := Write(&buf, a); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !intsEqual(got.Shape, a.Shape) || !floatsEqual(got.Data, a.Data) {
+		t.Errorf("Read(Write(%v)) = %v, want %v", a, got, a)
+	}
+}
+
+func TestArrayRoundTrip1D(t *testing.T) {
+	a := Array{Shape: []int{4}, Data: []float32{1, 2, 3, 4}}
+	var buf bytes.Buffer
+	if err := Write(&buf, a); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !intsEqual(got.Shape, a.Shape) || !floatsEqual(got.Data, a.Data) {
+		t.Errorf("Read(Write(%v)) = %v, want %v", a, got, a)
+	}
+}
+
+func TestZipRoundTrip(t *testing.T) {
+	arrays := map[string]Array{
+		"a": {Shape: []int{2}, Data: []float32{1, 2}},
+		"b": {Shape: []int{3}, Data: []float32{3, 4, 5}},
+	}
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, arrays); err != nil {
+		t.Fatalf("WriteZip: %v", err)
+	}
+	got, err := ReadZip(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadZip: %v", err)
+	}
+	if len(got) != len(arrays) {
+		t.Fatalf("ReadZip returned %d entries, want %d", len(got), len(arrays))
+	}
+	for name, want := range arrays {
+		a, ok := got[name]
+		if !ok {
+			t.Fatalf("ReadZip result missing entry %q", name)
+		}
+		if !intsEqual(a.Shape, want.Shape) || !floatsEqual(a.Data, want.Data) {
+			t.Errorf("ReadZip()[%q] = %v, want %v", name, a, want)
+		}
+	}
+}
+
+func TestSaveLoadModel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := &nann.Model{}
+	m.AddLayer(nann.NewDenseLayer(3, 4), nann.LRelu())
+	m.AddLayer(nann.NewDenseLayer(4, 2), nann.Ident())
+	m.InitWeights(r)
+
+	in := []float32{0.5, -0.3, 0.8}
+	want := [][]float32{make([]float32, 2)}
+	m.ForwardBatch([][]float32{in}, want)
+
+	var buf bytes.Buffer
+	if err := SaveModel(&buf, m); err != nil {
+		t.Fatalf("SaveModel: %v", err)
+	}
+
+	m2 := &nann.Model{}
+	m2.AddLayer(nann.NewDenseLayer(3, 4), nann.LRelu())
+	m2.AddLayer(nann.NewDenseLayer(4, 2), nann.Ident())
+	if err := LoadModel(bytes.NewReader(buf.Bytes()), m2); err != nil {
+		t.Fatalf("LoadModel: %v", err)
+	}
+
+	got := [][]float32{make([]float32, 2)}
+	m2.ForwardBatch([][]float32{in}, got)
+	if !floatsEqual(got[0], want[0]) {
+		t.Errorf("reloaded model output = %v, want %v", got, want)
+	}
+}
+
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, x := range a {
+		if x != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func floatsEqual(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, x := range a {
+		if d := x - b[i]; d > 1e-4 || d < -1e-4 {
+			return false
+		}
+	}
+	return true
+}
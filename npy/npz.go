@@ -0,0 +1,57 @@
+package npy
+
+import (
+	"archive/zip"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteZip writes arrays as an uncompressed .npz archive to w, one .npy
+// entry per name (a ".npy" suffix is appended if missing).
+func WriteZip(w io.Writer, arrays map[string]Array) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		fname := name
+		if !strings.HasSuffix(fname, ".npy") {
+			fname += ".npy"
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: fname, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := Write(fw, arrays[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ReadZip reads every .npy entry from an .npz archive, keyed by its name
+// with the ".npy" suffix stripped.
+func ReadZip(r io.ReaderAt, size int64) (map[string]Array, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make(map[string]Array, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		a, err := Read(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = a
+	}
+	return arrays, nil
+}
@@ -0,0 +1,191 @@
+package nann
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// QuantDenseLayer is a DenseLayer whose weights are stored as Fixed8 values
+// in a single contiguous row-major slice, with a single per-tensor float32
+// scale factor, so that Forward can accumulate int8 products in int32 and
+// apply the scale once at the end.
+type QuantDenseLayer struct {
+	fanIn, fanOut int
+	weights       []Fixed8 // row-major, len == fanIn*fanOut.
+	scale         float32
+
+	// shadow holds a float32 copy of the weights used for gradient updates
+	// during quantization-aware training. It is nil unless QAT is enabled
+	// via EnableQAT.
+	shadow DenseLayer
+}
+
+// NewQuantDenseLayer allocates a zeroed QuantDenseLayer of the given shape.
+func NewQuantDenseLayer(fanIn, fanOut int) *QuantDenseLayer {
+	return &QuantDenseLayer{
+		fanIn:   fanIn,
+		fanOut:  fanOut,
+		weights: make([]Fixed8, fanIn*fanOut),
+	}
+}
+
+// Quantize converts d to a QuantDenseLayer, computing a single per-tensor
+// scale factor from d's largest-magnitude weight and rounding every weight
+// to the nearest representable Fixed8 (saturating to [-16, 15.875]).
+func Quantize(d DenseLayer) *QuantDenseLayer {
+	fanIn, fanOut := d.Shape()
+	q := NewQuantDenseLayer(fanIn, fanOut)
+	q.scale = quantScale(d)
+	for i, row := range d {
+		for j, w := range row {
+			q.weights[i*fanOut+j] = NewFixed8(w / q.scale)
+		}
+	}
+	return q
+}
+
+func quantScale(d DenseLayer) float32 {
+	var mx float32
+	for _, row := range d {
+		for _, w := range row {
+			if a := abs(w); a > mx {
+				mx = a
+			}
+		}
+	}
+	return scaleFor(mx)
+}
+
+// scaleFor returns the per-tensor scale factor mapping a tensor with the
+// given max-magnitude element onto Fixed8's representable range.
+func scaleFor(maxAbs float32) float32 {
+	if maxAbs == 0 {
+		return 1
+	}
+	return maxAbs / fixed8Max
+}
+
+func maxAbs(xs []float32) float32 {
+	var mx float32
+	for _, x := range xs {
+		if a := abs(x); a > mx {
+			mx = a
+		}
+	}
+	return mx
+}
+
+// Dequantize returns a float32 DenseLayer reconstructed from the quantized
+// weights; the round trip through Fixed8 loses precision.
+func (l *QuantDenseLayer) Dequantize() DenseLayer {
+	d := NewDenseLayer(l.fanIn, l.fanOut)
+	for i, row := range d {
+		for j := range row {
+			row[j] = l.weights[i*l.fanOut+j].Float32() * l.scale
+		}
+	}
+	return d
+}
+
+// EnableQAT switches the layer into quantization-aware training mode: each
+// Forward call fake-quantizes a float32 shadow copy of the weights (so
+// gradients flow through full precision values) before using the
+// fake-quantized values to compute the layer's output.
+func (l *QuantDenseLayer) EnableQAT() {
+	if l.shadow == nil {
+		l.shadow = l.Dequantize()
+	}
+}
+
+// DisableQAT exits QAT mode. The quantized weights are left as the
+// fake-quantized values from the most recent Forward call.
+func (l *QuantDenseLayer) DisableQAT() { l.shadow = nil }
+
+// Shadow returns the float32 shadow weights used in QAT mode, or nil if QAT
+// is not enabled. Callers may update it in place (e.g. from a Trainer) to
+// apply gradients at full precision between Forward calls.
+func (l *QuantDenseLayer) Shadow() DenseLayer { return l.shadow }
+
+// Backward implements Trainable by delegating to the float32 shadow weights,
+// so gradients flow at full precision during QAT. It panics if QAT is not
+// enabled via EnableQAT.
+func (l *QuantDenseLayer) Backward(in, gradOut []float32, gradW [][]float32) []float32 {
+	if l.shadow == nil {
+		panic("nann: QuantDenseLayer.Backward requires EnableQAT")
+	}
+	return l.shadow.Backward(in, gradOut, gradW)
+}
+
+// Weights implements Parameterized by returning the float32 shadow weights,
+// so Trainer updates them in place during QAT; the next Forward call then
+// fake-quantizes the updated shadow. It panics if QAT is not enabled via
+// EnableQAT.
+func (l *QuantDenseLayer) Weights() [][]float32 {
+	if l.shadow == nil {
+		panic("nann: QuantDenseLayer.Weights requires EnableQAT")
+	}
+	return l.shadow
+}
+
+func (l *QuantDenseLayer) Shape() (int, int) { return l.fanIn, l.fanOut }
+
+func (l *QuantDenseLayer) DType() DType { return DTypeFixed8 }
+
+func (l *QuantDenseLayer) InitWeights(r *rand.Rand) {
+	d := NewDenseLayer(l.fanIn, l.fanOut)
+	d.InitWeights(r)
+	*l = *Quantize(d)
+}
+
+func (l *QuantDenseLayer) Forward(in, out []float32) []float32 {
+	if len(in) != l.fanIn {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if len(out) != l.fanOut {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(out)), 10) + ")")
+	}
+	if l.shadow != nil {
+		return l.forwardQAT(in, out)
+	}
+
+	inScale := scaleFor(maxAbs(in))
+	acc := make([]int32, l.fanOut)
+	for i, x := range in {
+		xi := int32(NewFixed8(x / inScale))
+		row := l.weights[i*l.fanOut : (i+1)*l.fanOut]
+		for j, w := range row {
+			acc[j] += xi * int32(w)
+		}
+	}
+	s := l.scale * inScale * fixed8Scale * fixed8Scale
+	for j, a := range acc {
+		out[j] = float32(a) * s
+	}
+	return out
+}
+
+// forwardQAT recomputes the per-tensor scale from the shadow weights,
+// fake-quantizes them into l.weights, and runs the matmul in float32 using
+// the fake-quantized (but dequantized) values.
+func (l *QuantDenseLayer) forwardQAT(in, out []float32) []float32 {
+	l.scale = quantScale(l.shadow)
+	for j := range out {
+		out[j] = 0
+	}
+	for i, x := range in {
+		row := l.shadow[i]
+		for j, w := range row {
+			fq := NewFixed8(w / l.scale)
+			l.weights[i*l.fanOut+j] = fq
+			out[j] += fq.Float32() * l.scale * x
+		}
+	}
+	return out
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,154 @@
+package nann
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// toyDigits are 5x5 binary stroke patterns for three toy "digit" classes,
+// flattened row-major, used as class prototypes for a small synthetic
+// classification problem.
+var toyDigits = [][]float32{
+	{ // class 0: a ring
+		1, 1, 1, 1, 1,
+		1, 0, 0, 0, 1,
+		1, 0, 0, 0, 1,
+		1, 0, 0, 0, 1,
+		1, 1, 1, 1, 1,
+	},
+	{ // class 1: a vertical bar
+		0, 0, 1, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 1, 0, 0,
+	},
+	{ // class 2: a diagonal stroke
+		1, 0, 0, 0, 0,
+		0, 1, 0, 0, 0,
+		0, 0, 1, 0, 0,
+		0, 0, 0, 1, 0,
+		0, 0, 0, 0, 1,
+	},
+}
+
+func newToySample(r *rand.Rand, class int) (in, target []float32) {
+	in = make([]float32, len(toyDigits[class]))
+	for i, x := range toyDigits[class] {
+		in[i] = x + 0.2*float32(r.NormFloat64())
+	}
+	target = make([]float32, len(toyDigits))
+	target[class] = 1
+	return in, target
+}
+
+func newToyModel(r *rand.Rand) *Model {
+	m := &Model{}
+	m.AddLayer(NewDenseLayer(len(toyDigits[0]), 12), LRelu())
+	m.AddLayer(NewDenseLayer(12, len(toyDigits)), Ident())
+	m.InitWeights(r)
+	return m
+}
+
+func argmax(xs []float32) int {
+	best := 0
+	for i, x := range xs {
+		if x > xs[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// accuracy classifies n toy samples against m. It calls forwardScratch
+// directly, rather than Forward, so repeated calls don't each allocate a
+// fresh pair of scratch buffers.
+func accuracy(m *Model, r *rand.Rand, n int) float64 {
+	scratch := m.newScratch()
+	_, lastOut := m.Shape()
+	out := make([]float32, lastOut)
+	correct := 0
+	for i := 0; i < n; i++ {
+		class := i % len(toyDigits)
+		in, _ := newToySample(r, class)
+		out = m.forwardScratch(in, out, scratch)
+		if argmax(out) == class {
+			correct++
+		}
+	}
+	return float64(correct) / float64(n)
+}
+
+// TestQuantDenseLayerAccuracy trains a small float32 model on a toy
+// MNIST-like classification problem, quantizes its DenseLayers to Fixed8,
+// and checks that the quantized model's accuracy doesn't drop far below the
+// float32 model it was derived from.
+func TestQuantDenseLayerAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := newToyModel(r)
+
+	tr := NewTrainer(m, CrossEntropy(), Adam(0.05))
+	for epoch := 0; epoch < 200; epoch++ {
+		var inputs, targets [][]float32
+		for class := range toyDigits {
+			in, target := newToySample(r, class)
+			inputs = append(inputs, in)
+			targets = append(targets, target)
+		}
+		tr.Step(inputs, targets)
+	}
+
+	floatAcc := accuracy(m, r, 300)
+	if floatAcc < 0.9 {
+		t.Fatalf("float32 model accuracy = %v, want >= 0.9 (test setup, not Quantize, looks broken)", floatAcc)
+	}
+
+	qm := &Model{Biases: m.Biases, ActivFn: m.ActivFn}
+	for _, l := range m.Layers {
+		qm.Layers = append(qm.Layers, Quantize(l.(DenseLayer)))
+	}
+	quantAcc := accuracy(qm, r, 300)
+
+	if loss := floatAcc - quantAcc; loss > 0.15 {
+		t.Errorf("quantized accuracy = %v, float32 accuracy = %v, loss = %v exceeds 0.15", quantAcc, floatAcc, loss)
+	}
+}
+
+// TestQuantDenseLayerQAT trains a QuantDenseLayer with QAT enabled through a
+// Trainer and checks the shadow weights actually move, exercising the
+// Backward/Weights support EnableQAT depends on.
+func TestQuantDenseLayerQAT(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	d := NewDenseLayer(len(toyDigits[0]), len(toyDigits))
+	d.InitWeights(r)
+	q := Quantize(d)
+	q.EnableQAT()
+	before := cloneDenseLayer(q.Shadow())
+
+	m := &Model{}
+	m.AddLayer(q, Ident())
+
+	tr := NewTrainer(m, CrossEntropy(), SGD(0.5))
+	for epoch := 0; epoch < 20; epoch++ {
+		var inputs, targets [][]float32
+		for class := range toyDigits {
+			in, target := newToySample(r, class)
+			inputs = append(inputs, in)
+			targets = append(targets, target)
+		}
+		tr.Step(inputs, targets)
+	}
+
+	after := q.Shadow()
+	var moved bool
+	for i, row := range before {
+		for j, w := range row {
+			if d := after[i][j] - w; d > 1e-6 || d < -1e-6 {
+				moved = true
+			}
+		}
+	}
+	if !moved {
+		t.Errorf("QAT shadow weights did not change after training through Trainer")
+	}
+}
@@ -0,0 +1,358 @@
+package nann
+
+import (
+	"math"
+	"strconv"
+)
+
+// Trainable is implemented by layers that support gradient-based training.
+// DenseLayer and *QuantDenseLayer (whose Backward requires QAT mode) are the
+// built-in implementations; layers that only support inference (e.g.
+// half-precision or convolutional layers) need not implement it.
+type Trainable interface {
+	Layer
+
+	// Backward computes the gradient of the loss w.r.t. this layer's input
+	// (gradIn) given in, the forward-pass input, and gradOut, the gradient
+	// of the loss w.r.t. this layer's pre-activation output. The weight
+	// gradient is accumulated (added) into gradW, which must have the same
+	// shape as the layer's weights.
+	Backward(in, gradOut []float32, gradW [][]float32) (gradIn []float32)
+}
+
+// Parameterized is implemented by Trainable layers that expose their weight
+// matrix directly, letting Trainer update it in place via an Optimizer
+// without hardcoding a concrete layer type.
+type Parameterized interface {
+	Trainable
+	// Weights returns the layer's weight matrix, shaped like the gradW
+	// passed to Backward, for Trainer to update in place.
+	Weights() [][]float32
+}
+
+func (l DenseLayer) Weights() [][]float32 { return l }
+
+func (l DenseLayer) Backward(in, gradOut []float32, gradW [][]float32) []float32 {
+	if len(in) != len(l) {
+		panic("shape mismatch (" + strconv.FormatInt(int64(len(in)), 10) + ", _)")
+	}
+	if len(gradOut) != len(l[0]) {
+		panic("shape mismatch (_, " + strconv.FormatInt(int64(len(gradOut)), 10) + ")")
+	}
+	gradIn := make([]float32, len(l))
+	for i, x := range in {
+		row, gradRow := l[i], gradW[i]
+		var gi float32
+		for j, g := range gradOut {
+			gradRow[j] += x * g
+			gi += row[j] * g
+		}
+		gradIn[i] = gi
+	}
+	return gradIn
+}
+
+// Loss computes a scalar loss and its gradient w.r.t. the predicted output.
+type Loss interface {
+	// Loss returns the scalar loss of pred against target.
+	Loss(pred, target []float32) float32
+	// Grad writes the gradient of the loss w.r.t. pred into grad.
+	Grad(pred, target, grad []float32)
+}
+
+type mseLoss struct{}
+
+// MSE returns a Loss computing mean squared error.
+func MSE() Loss { return mseLoss{} }
+
+func (mseLoss) Loss(pred, target []float32) float32 {
+	var sum float32
+	for i, p := range pred {
+		d := p - target[i]
+		sum += d * d
+	}
+	return sum / float32(len(pred))
+}
+
+func (mseLoss) Grad(pred, target, grad []float32) {
+	n := float32(len(pred))
+	for i, p := range pred {
+		grad[i] = 2 * (p - target[i]) / n
+	}
+}
+
+type crossEntropyLoss struct{}
+
+// CrossEntropy returns a Loss fusing Softmax with categorical cross-entropy
+// for numerical stability. pred is expected to be raw (pre-softmax) logits;
+// target is a one-hot (or soft) distribution over the same classes.
+func CrossEntropy() Loss { return crossEntropyLoss{} }
+
+func (crossEntropyLoss) Loss(pred, target []float32) float32 {
+	logSumExp := logSumExp(pred)
+	var loss float32
+	for i, t := range target {
+		if t == 0 {
+			continue
+		}
+		loss -= t * (pred[i] - logSumExp)
+	}
+	return loss
+}
+
+func (crossEntropyLoss) Grad(pred, target, grad []float32) {
+	mx := maxOf(pred)
+	sp := softmaxd(shift(pred, mx))
+	for i, x := range pred {
+		grad[i] = exp(x-mx)/sp - target[i]
+	}
+}
+
+type binaryCrossEntropyLoss struct{}
+
+// BinaryCrossEntropy returns a Loss computing binary cross-entropy, treating
+// each element of pred/target as an independent Bernoulli probability.
+func BinaryCrossEntropy() Loss { return binaryCrossEntropyLoss{} }
+
+func (binaryCrossEntropyLoss) Loss(pred, target []float32) float32 {
+	var sum float32
+	for i, p := range pred {
+		p = clamp(p, epsBCE, 1-epsBCE)
+		t := target[i]
+		sum -= t*log(p) + (1-t)*log(1-p)
+	}
+	return sum / float32(len(pred))
+}
+
+func (binaryCrossEntropyLoss) Grad(pred, target, grad []float32) {
+	n := float32(len(pred))
+	for i, p := range pred {
+		p = clamp(p, epsBCE, 1-epsBCE)
+		grad[i] = (p - target[i]) / (p * (1 - p) * n)
+	}
+}
+
+const epsBCE = 1e-7
+
+func clamp(x, lo, hi float32) float32 {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+func log(x float32) float32 { return float32(math.Log(float64(x))) }
+
+func maxOf(xs []float32) float32 {
+	mx := xs[0]
+	for _, x := range xs[1:] {
+		if x > mx {
+			mx = x
+		}
+	}
+	return mx
+}
+
+func shift(xs []float32, by float32) []float32 {
+	out := make([]float32, len(xs))
+	for i, x := range xs {
+		out[i] = x - by
+	}
+	return out
+}
+
+func logSumExp(xs []float32) float32 {
+	mx := maxOf(xs)
+	return mx + log(softmaxd(shift(xs, mx)))
+}
+
+// OptimState holds the per-parameter state an Optimizer needs across steps
+// (e.g. momentum, or Adam's first/second moment estimates). Callers must
+// keep one OptimState per logical parameter slice (e.g. one per DenseLayer
+// weight row, plus one for its bias) and reuse it across Step calls.
+type OptimState struct {
+	m, v []float32 // first/second moment estimates; m doubles as momentum for SGDMomentum.
+	t    int       // time step, incremented on each Adam Step call.
+}
+
+// Optimizer updates params in place given their gradient.
+type Optimizer interface {
+	// Step updates params in place using grad, maintaining any accumulator
+	// state (momentum, moment estimates, ...) in state across calls.
+	Step(params, grad []float32, state *OptimState)
+}
+
+type sgd struct{ lr float32 }
+
+// SGD returns an Optimizer performing vanilla stochastic gradient descent
+// with learning rate lr.
+func SGD(lr float32) Optimizer { return sgd{lr} }
+
+func (o sgd) Step(params, grad []float32, _ *OptimState) {
+	for i, g := range grad {
+		params[i] -= o.lr * g
+	}
+}
+
+type sgdMomentum struct{ lr, momentum float32 }
+
+// SGDMomentum returns an Optimizer performing SGD with classical momentum.
+func SGDMomentum(lr, momentum float32) Optimizer { return sgdMomentum{lr, momentum} }
+
+func (o sgdMomentum) Step(params, grad []float32, s *OptimState) {
+	if len(s.m) != len(params) {
+		s.m = make([]float32, len(params))
+	}
+	for i, g := range grad {
+		s.m[i] = o.momentum*s.m[i] + g
+		params[i] -= o.lr * s.m[i]
+	}
+}
+
+const (
+	adamBeta1   = 0.9
+	adamBeta2   = 0.999
+	adamEpsilon = 1e-8
+)
+
+type adam struct{ lr float32 }
+
+// Adam returns an Optimizer implementing the Adam update rule with
+// β1=0.9, β2=0.999, ε=1e-8.
+func Adam(lr float32) Optimizer { return adam{lr} }
+
+func (o adam) Step(params, grad []float32, s *OptimState) {
+	if len(s.m) != len(params) {
+		s.m = make([]float32, len(params))
+		s.v = make([]float32, len(params))
+	}
+	s.t++
+	bc1 := 1 - pow32(adamBeta1, float32(s.t))
+	bc2 := 1 - pow32(adamBeta2, float32(s.t))
+	for i, g := range grad {
+		s.m[i] = adamBeta1*s.m[i] + (1-adamBeta1)*g
+		s.v[i] = adamBeta2*s.v[i] + (1-adamBeta2)*g*g
+		mHat := s.m[i] / bc1
+		vHat := s.v[i] / bc2
+		params[i] -= o.lr * mHat / (sqrt(vHat) + adamEpsilon)
+	}
+}
+
+func pow32(x, y float32) float32 { return float32(math.Pow(float64(x), float64(y))) }
+
+// Trainer runs mini-batch gradient descent over a Model, caching the
+// per-layer pre-activation and post-activation values it needs for
+// Backward between calls to Step.
+type Trainer struct {
+	Model *Model
+	Loss  Loss
+	Opt   Optimizer
+
+	preAct  [][]float32    // preAct[i]: pre-activation output of layer i.
+	postAct [][]float32    // postAct[0]: model input; postAct[i+1]: post-activation output of layer i.
+	gradW   [][][]float32  // gradW[i]: weight gradient accumulator for layer i, shaped like its weights.
+	wState  [][]OptimState // wState[i][j]: optimizer state for row j of layer i's weights.
+	bState  []OptimState   // bState[i]: optimizer state for layer i's bias.
+}
+
+// NewTrainer returns a Trainer that trains m against loss using opt.
+func NewTrainer(m *Model, loss Loss, opt Optimizer) *Trainer {
+	return &Trainer{Model: m, Loss: loss, Opt: opt}
+}
+
+func (t *Trainer) init() {
+	if t.preAct != nil {
+		return
+	}
+	n := len(t.Model.Layers)
+	t.preAct = make([][]float32, n)
+	t.postAct = make([][]float32, n+1)
+	t.gradW = make([][][]float32, n)
+	t.wState = make([][]OptimState, n)
+	t.bState = make([]OptimState, n)
+	for i, l := range t.Model.Layers {
+		fanIn, fanOut := l.Shape()
+		t.preAct[i] = make([]float32, fanOut)
+		t.postAct[i+1] = make([]float32, fanOut)
+		gw := make([][]float32, fanIn)
+		for j := range gw {
+			gw[j] = make([]float32, fanOut)
+		}
+		t.gradW[i] = gw
+		t.wState[i] = make([]OptimState, fanIn)
+	}
+}
+
+// Step runs one mini-batch gradient descent step, pairing each sample in
+// inputs with the corresponding element of targets, and returns the
+// average loss over the batch. Backward propagation stops at the first
+// layer (counting from the output) that does not implement Trainable, so
+// only a trailing run of trainable layers is updated.
+func (t *Trainer) Step(inputs, targets [][]float32) float32 {
+	t.init()
+	m := t.Model
+	for _, gw := range t.gradW {
+		for _, row := range gw {
+			clear(row)
+		}
+	}
+	gradB := make([]float32, len(m.Layers))
+	_, lastOut := m.Shape()
+	var totalLoss float32
+	for bi, in := range inputs {
+		t.postAct[0] = in
+		for i, l := range m.Layers {
+			z := t.preAct[i]
+			clear(z)
+			z = l.Forward(t.postAct[i], z)
+			b, a := m.Biases[i], m.ActivFn[i]
+			out := t.postAct[i+1]
+			for j, x := range z {
+				z[j] = x + b
+				out[j] = a.Apply(z[j])
+			}
+		}
+
+		pred := t.postAct[len(m.Layers)]
+		totalLoss += t.Loss.Loss(pred, targets[bi])
+		gradOut := make([]float32, lastOut)
+		t.Loss.Grad(pred, targets[bi], gradOut)
+
+		for i := len(m.Layers) - 1; i >= 0; i-- {
+			tl, ok := m.Layers[i].(Trainable)
+			if !ok {
+				break
+			}
+			a := m.ActivFn[i]
+			out := t.postAct[i+1]
+			dz := make([]float32, len(out))
+			for j, g := range gradOut {
+				dz[j] = g * a.Deriv(out[j])
+				gradB[i] += dz[j]
+			}
+			gradOut = tl.Backward(t.postAct[i], dz, t.gradW[i])
+		}
+	}
+
+	n := float32(len(inputs))
+	for i, l := range m.Layers {
+		p, ok := l.(Parameterized)
+		if !ok {
+			continue
+		}
+		for j, row := range p.Weights() {
+			gradRow := t.gradW[i][j]
+			for k := range gradRow {
+				gradRow[k] /= n
+			}
+			t.Opt.Step(row, gradRow, &t.wState[i][j])
+		}
+		bias := [...]float32{m.Biases[i]}
+		t.Opt.Step(bias[:], []float32{gradB[i] / n}, &t.bState[i])
+		m.Biases[i] = bias[0]
+	}
+	return totalLoss / n
+}